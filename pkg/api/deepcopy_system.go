@@ -0,0 +1,68 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+// DeepCopy creates a deep copy of a ServiceAccountTokenProjection. It's
+// hand-written rather than generated because this type was added ahead of
+// running deepcopy-gen for this series.
+func (in *ServiceAccountTokenProjection) DeepCopy() *ServiceAccountTokenProjection {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountTokenProjection)
+	*out = *in
+	if in.ExpirationSeconds != nil {
+		out.ExpirationSeconds = new(int64)
+		*out.ExpirationSeconds = *in.ExpirationSeconds
+	}
+	if in.Mode != nil {
+		out.Mode = new(int32)
+		*out.Mode = *in.Mode
+	}
+	return out
+}
+
+// DeepCopy creates a deep copy of a SystemVolumeProjection.
+func (in *SystemVolumeProjection) DeepCopy() *SystemVolumeProjection {
+	if in == nil {
+		return nil
+	}
+	out := new(SystemVolumeProjection)
+	*out = *in
+	out.ServiceAccountToken = in.ServiceAccountToken.DeepCopy()
+	return out
+}
+
+// DeepCopy creates a deep copy of a SystemProjections, including each of
+// its Sources.
+func (in *SystemProjections) DeepCopy() *SystemProjections {
+	if in == nil {
+		return nil
+	}
+	out := new(SystemProjections)
+	if in.DefaultMode != nil {
+		out.DefaultMode = new(int32)
+		*out.DefaultMode = *in.DefaultMode
+	}
+	if in.Sources != nil {
+		out.Sources = make([]SystemVolumeProjection, len(in.Sources))
+		for i := range in.Sources {
+			out.Sources[i] = *in.Sources[i].DeepCopy()
+		}
+	}
+	return out
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+// SystemProjections represents a projected volume made up of one or more
+// Sources, each contributing files into the same tree.
+type SystemProjections struct {
+	Sources []SystemVolumeProjection
+	// DefaultMode are the mode bits used to set permissions on created
+	// files by default, applied when a Source doesn't specify its own.
+	DefaultMode *int32
+}
+
+// SystemVolumeProjection is a projection that may be projected along with
+// other supported volume types. Exactly one of its fields should be
+// non-nil.
+type SystemVolumeProjection struct {
+	Secret              *SecretProjection
+	DownwardAPI         *DownwardAPIProjection
+	ConfigMap           *ConfigMapProjection
+	ServiceAccountToken *ServiceAccountTokenProjection
+}
+
+// SecretProjection adapts a secret into a projected volume source.
+type SecretProjection struct {
+	SecretName string
+	Items      []KeyToPath
+}
+
+// ConfigMapProjection adapts a ConfigMap into a projected volume source.
+type ConfigMapProjection struct {
+	Name  string
+	Items []KeyToPath
+}
+
+// DownwardAPIProjection represents downward API info for projecting into a
+// projected volume. Note that this is identical to a downwardAPI volume
+// source without the default mode.
+type DownwardAPIProjection struct {
+	Items []DownwardAPIVolumeFile
+}
+
+// ServiceAccountTokenProjection projects a service account token for the
+// pod into the volume as a file, requested from the TokenRequest API
+// scoped to the given Audience and ExpirationSeconds.
+type ServiceAccountTokenProjection struct {
+	// Audience is the intended audience of the token. A recipient of a
+	// token must identify itself with an identifier specified in the
+	// audience of the token, and otherwise should reject the token. The
+	// audience defaults to the identifier of the apiserver.
+	Audience string
+	// ExpirationSeconds is the requested duration of validity of the
+	// requested token. As the token approaches expiration, the kubelet
+	// volume plugin will proactively rotate the service account token.
+	// The kubelet will start trying to rotate the token if the token is
+	// older than 80 percent of its time to live or if the token is
+	// older than 24 hours. Defaults to 1 hour and must be at least 10
+	// minutes.
+	ExpirationSeconds *int64
+	// Path is the relative path of the file to project the token into.
+	Path string
+	// Mode are the mode bits to use on this file, defaulting to the
+	// enclosing SystemProjections' DefaultMode if unset.
+	Mode *int32
+}
+
+// KeyToPath maps a string key to a path within a volume.
+type KeyToPath struct {
+	Key  string
+	Path string
+	Mode *int32
+}
+
+// DownwardAPIVolumeFile represents information to create the file
+// containing the pod field.
+type DownwardAPIVolumeFile struct {
+	Path             string
+	FieldRef         *ObjectFieldSelector
+	ResourceFieldRef *ResourceFieldSelector
+	Mode             *int32
+}
+
+// ObjectFieldSelector selects an APIVersioned field of an object.
+type ObjectFieldSelector struct {
+	APIVersion string
+	FieldPath  string
+}
+
+// ResourceFieldSelector represents container resources (cpu, memory) and
+// their output format.
+type ResourceFieldSelector struct {
+	ContainerName string
+	Resource      string
+}
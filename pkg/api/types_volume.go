@@ -0,0 +1,82 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"k8s.io/kubernetes/pkg/types"
+)
+
+// StorageMedium defines ways that storage can be allocated to a volume.
+type StorageMedium string
+
+const (
+	StorageMediumDefault StorageMedium = ""
+	StorageMediumMemory  StorageMedium = "Memory"
+)
+
+// EmptyDirVolumeSource is a temporary directory that shares a pod's
+// lifetime.
+type EmptyDirVolumeSource struct {
+	Medium StorageMedium
+}
+
+// VolumeSource represents the location and type of the volume to mount.
+// Only a subset of fields this repo's volume plugins understand are
+// declared here.
+type VolumeSource struct {
+	EmptyDir         *EmptyDirVolumeSource
+	SystemProjection *SystemProjections
+}
+
+// Volume represents a named volume in a pod that may be accessed by any
+// container in the pod.
+type Volume struct {
+	Name string
+	VolumeSource
+}
+
+// ObjectMeta is the metadata all persisted resources share.
+type ObjectMeta struct {
+	Name      string
+	Namespace string
+	UID       types.UID
+}
+
+// PodSpec is a description of a pod.
+type PodSpec struct {
+	ServiceAccountName string
+	Volumes            []Volume
+}
+
+// Pod is a collection of containers that can run on a node.
+type Pod struct {
+	ObjectMeta
+	Spec PodSpec
+}
+
+// Secret holds secret data, referenced by name from a SecretProjection.
+type Secret struct {
+	ObjectMeta
+	Data map[string][]byte
+}
+
+// ConfigMap holds configuration data, referenced by name from a
+// ConfigMapProjection.
+type ConfigMap struct {
+	ObjectMeta
+	Data map[string]string
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"k8s.io/kubernetes/pkg/api"
+)
+
+// Convert_v1_ServiceAccountTokenProjection_To_api_ServiceAccountTokenProjection
+// converts the versioned ServiceAccountTokenProjection to its internal
+// representation. It's hand-written rather than generated because this
+// type was added ahead of running conversion-gen for this series.
+func Convert_v1_ServiceAccountTokenProjection_To_api_ServiceAccountTokenProjection(in *ServiceAccountTokenProjection, out *api.ServiceAccountTokenProjection) error {
+	out.Audience = in.Audience
+	out.ExpirationSeconds = in.ExpirationSeconds
+	out.Path = in.Path
+	out.Mode = in.Mode
+	return nil
+}
+
+// Convert_api_ServiceAccountTokenProjection_To_v1_ServiceAccountTokenProjection
+// is the reverse of Convert_v1_ServiceAccountTokenProjection_To_api_ServiceAccountTokenProjection.
+func Convert_api_ServiceAccountTokenProjection_To_v1_ServiceAccountTokenProjection(in *api.ServiceAccountTokenProjection, out *ServiceAccountTokenProjection) error {
+	out.Audience = in.Audience
+	out.ExpirationSeconds = in.ExpirationSeconds
+	out.Path = in.Path
+	out.Mode = in.Mode
+	return nil
+}
+
+// Convert_v1_SystemVolumeProjection_To_api_SystemVolumeProjection converts
+// the versioned SystemVolumeProjection, including its ServiceAccountToken
+// field, to its internal representation.
+func Convert_v1_SystemVolumeProjection_To_api_SystemVolumeProjection(in *SystemVolumeProjection, out *api.SystemVolumeProjection) error {
+	if in.Secret != nil {
+		out.Secret = &api.SecretProjection{SecretName: in.Secret.SecretName}
+		for _, item := range in.Secret.Items {
+			out.Secret.Items = append(out.Secret.Items, api.KeyToPath{Key: item.Key, Path: item.Path, Mode: item.Mode})
+		}
+	}
+	if in.ConfigMap != nil {
+		out.ConfigMap = &api.ConfigMapProjection{Name: in.ConfigMap.Name}
+		for _, item := range in.ConfigMap.Items {
+			out.ConfigMap.Items = append(out.ConfigMap.Items, api.KeyToPath{Key: item.Key, Path: item.Path, Mode: item.Mode})
+		}
+	}
+	if in.DownwardAPI != nil {
+		out.DownwardAPI = &api.DownwardAPIProjection{}
+		for _, item := range in.DownwardAPI.Items {
+			out.DownwardAPI.Items = append(out.DownwardAPI.Items, convertDownwardAPIVolumeFileToAPI(item))
+		}
+	}
+	if in.ServiceAccountToken != nil {
+		out.ServiceAccountToken = &api.ServiceAccountTokenProjection{}
+		if err := Convert_v1_ServiceAccountTokenProjection_To_api_ServiceAccountTokenProjection(in.ServiceAccountToken, out.ServiceAccountToken); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Convert_api_SystemVolumeProjection_To_v1_SystemVolumeProjection is the
+// reverse of Convert_v1_SystemVolumeProjection_To_api_SystemVolumeProjection.
+func Convert_api_SystemVolumeProjection_To_v1_SystemVolumeProjection(in *api.SystemVolumeProjection, out *SystemVolumeProjection) error {
+	if in.Secret != nil {
+		out.Secret = &SecretProjection{SecretName: in.Secret.SecretName}
+		for _, item := range in.Secret.Items {
+			out.Secret.Items = append(out.Secret.Items, KeyToPath{Key: item.Key, Path: item.Path, Mode: item.Mode})
+		}
+	}
+	if in.ConfigMap != nil {
+		out.ConfigMap = &ConfigMapProjection{Name: in.ConfigMap.Name}
+		for _, item := range in.ConfigMap.Items {
+			out.ConfigMap.Items = append(out.ConfigMap.Items, KeyToPath{Key: item.Key, Path: item.Path, Mode: item.Mode})
+		}
+	}
+	if in.DownwardAPI != nil {
+		out.DownwardAPI = &DownwardAPIProjection{}
+		for _, item := range in.DownwardAPI.Items {
+			out.DownwardAPI.Items = append(out.DownwardAPI.Items, convertDownwardAPIVolumeFileFromAPI(item))
+		}
+	}
+	if in.ServiceAccountToken != nil {
+		out.ServiceAccountToken = &ServiceAccountTokenProjection{}
+		if err := Convert_api_ServiceAccountTokenProjection_To_v1_ServiceAccountTokenProjection(in.ServiceAccountToken, out.ServiceAccountToken); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func convertDownwardAPIVolumeFileToAPI(in DownwardAPIVolumeFile) api.DownwardAPIVolumeFile {
+	out := api.DownwardAPIVolumeFile{Path: in.Path, Mode: in.Mode}
+	if in.FieldRef != nil {
+		out.FieldRef = &api.ObjectFieldSelector{APIVersion: in.FieldRef.APIVersion, FieldPath: in.FieldRef.FieldPath}
+	}
+	if in.ResourceFieldRef != nil {
+		out.ResourceFieldRef = &api.ResourceFieldSelector{ContainerName: in.ResourceFieldRef.ContainerName, Resource: in.ResourceFieldRef.Resource}
+	}
+	return out
+}
+
+func convertDownwardAPIVolumeFileFromAPI(in api.DownwardAPIVolumeFile) DownwardAPIVolumeFile {
+	out := DownwardAPIVolumeFile{Path: in.Path, Mode: in.Mode}
+	if in.FieldRef != nil {
+		out.FieldRef = &ObjectFieldSelector{APIVersion: in.FieldRef.APIVersion, FieldPath: in.FieldRef.FieldPath}
+	}
+	if in.ResourceFieldRef != nil {
+		out.ResourceFieldRef = &ResourceFieldSelector{ContainerName: in.ResourceFieldRef.ContainerName, Resource: in.ResourceFieldRef.Resource}
+	}
+	return out
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+// SystemProjections represents a projected volume made up of one or more
+// Sources, each contributing files into the same tree.
+type SystemProjections struct {
+	Sources []SystemVolumeProjection `json:"sources" protobuf:"bytes,1,rep,name=sources"`
+	// +optional
+	DefaultMode *int32 `json:"defaultMode,omitempty" protobuf:"varint,2,opt,name=defaultMode"`
+}
+
+// SystemVolumeProjection is a projection that may be projected along with
+// other supported volume types. Exactly one of its fields should be
+// non-nil.
+type SystemVolumeProjection struct {
+	// +optional
+	Secret *SecretProjection `json:"secret,omitempty" protobuf:"bytes,1,opt,name=secret"`
+	// +optional
+	DownwardAPI *DownwardAPIProjection `json:"downwardAPI,omitempty" protobuf:"bytes,2,opt,name=downwardAPI"`
+	// +optional
+	ConfigMap *ConfigMapProjection `json:"configMap,omitempty" protobuf:"bytes,3,opt,name=configMap"`
+	// +optional
+	ServiceAccountToken *ServiceAccountTokenProjection `json:"serviceAccountToken,omitempty" protobuf:"bytes,4,opt,name=serviceAccountToken"`
+}
+
+// ServiceAccountTokenProjection projects a service account token for the
+// pod into the volume as a file, requested from the TokenRequest API
+// scoped to the given Audience and ExpirationSeconds.
+type ServiceAccountTokenProjection struct {
+	// +optional
+	Audience string `json:"audience,omitempty" protobuf:"bytes,1,opt,name=audience"`
+	// +optional
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty" protobuf:"varint,2,opt,name=expirationSeconds"`
+	Path              string `json:"path" protobuf:"bytes,3,opt,name=path"`
+	// +optional
+	Mode *int32 `json:"mode,omitempty" protobuf:"varint,4,opt,name=mode"`
+}
+
+// SecretProjection adapts a secret into a projected volume source.
+type SecretProjection struct {
+	SecretName string      `json:"secretName,omitempty" protobuf:"bytes,1,opt,name=secretName"`
+	Items      []KeyToPath `json:"items,omitempty" protobuf:"bytes,2,rep,name=items"`
+}
+
+// ConfigMapProjection adapts a ConfigMap into a projected volume source.
+type ConfigMapProjection struct {
+	Name  string      `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
+	Items []KeyToPath `json:"items,omitempty" protobuf:"bytes,2,rep,name=items"`
+}
+
+// DownwardAPIProjection represents downward API info for projecting into a
+// projected volume.
+type DownwardAPIProjection struct {
+	Items []DownwardAPIVolumeFile `json:"items,omitempty" protobuf:"bytes,1,rep,name=items"`
+}
+
+// KeyToPath maps a string key to a path within a volume.
+type KeyToPath struct {
+	Key  string `json:"key" protobuf:"bytes,1,opt,name=key"`
+	Path string `json:"path" protobuf:"bytes,2,opt,name=path"`
+	// +optional
+	Mode *int32 `json:"mode,omitempty" protobuf:"varint,3,opt,name=mode"`
+}
+
+// DownwardAPIVolumeFile represents information to create the file
+// containing the pod field.
+type DownwardAPIVolumeFile struct {
+	Path string `json:"path" protobuf:"bytes,1,opt,name=path"`
+	// +optional
+	FieldRef *ObjectFieldSelector `json:"fieldRef,omitempty" protobuf:"bytes,2,opt,name=fieldRef"`
+	// +optional
+	ResourceFieldRef *ResourceFieldSelector `json:"resourceFieldRef,omitempty" protobuf:"bytes,3,opt,name=resourceFieldRef"`
+	// +optional
+	Mode *int32 `json:"mode,omitempty" protobuf:"varint,4,opt,name=mode"`
+}
+
+// ObjectFieldSelector selects an APIVersioned field of an object.
+type ObjectFieldSelector struct {
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty" protobuf:"bytes,1,opt,name=apiVersion"`
+	FieldPath  string `json:"fieldPath" protobuf:"bytes,2,opt,name=fieldPath"`
+}
+
+// ResourceFieldSelector represents container resources (cpu, memory) and
+// their output format.
+type ResourceFieldSelector struct {
+	// +optional
+	ContainerName string `json:"containerName,omitempty" protobuf:"bytes,1,opt,name=containerName"`
+	Resource      string `json:"resource" protobuf:"bytes,2,opt,name=resource"`
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/util/validation/field"
+)
+
+// ValidateVolumeSource validates the volume source set on an
+// api.VolumeSource. Only the SystemProjection case is checked; other volume
+// types pass without errors.
+func ValidateVolumeSource(source *api.VolumeSource, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if source.SystemProjection != nil {
+		allErrs = append(allErrs, validateSystemVolumeSource(source.SystemProjection, fldPath.Child("systemProjection", "sources"))...)
+	}
+
+	return allErrs
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/util/validation/field"
+)
+
+// validateSystemVolumeSource is called from ValidateVolumeSource's
+// SystemProjection case. It rejects specs where two sources would project
+// to the same path at admission, rather than leaving them to fail only at
+// SetUp time in pkg/volume/system's collectData.
+func validateSystemVolumeSource(system *api.SystemProjections, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	owners := map[string][]string{}
+	addPath := func(idx int, kind, key, targetPath string) {
+		if err := validateSystemProjectedPath(targetPath); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(idx).Child("path"), targetPath, err.Error()))
+			return
+		}
+		// Normalized the same way pkg/volume/system's claimPath does, so
+		// paths that are equivalent but spelled differently (e.g. "foo"
+		// vs "./foo") collide here too instead of only at mount time.
+		cleaned := path.Clean(targetPath)
+		owners[cleaned] = append(owners[cleaned], fmt.Sprintf("%s[%s]", kind, key))
+	}
+
+	for i, source := range system.Sources {
+		switch {
+		case source.Secret != nil:
+			for _, item := range source.Secret.Items {
+				addPath(i, "secret", source.Secret.SecretName, item.Path)
+			}
+		case source.ConfigMap != nil:
+			for _, item := range source.ConfigMap.Items {
+				addPath(i, "configMap", source.ConfigMap.Name, item.Path)
+			}
+		case source.DownwardAPI != nil:
+			for _, item := range source.DownwardAPI.Items {
+				addPath(i, "downwardAPI", item.Path, item.Path)
+			}
+		case source.ServiceAccountToken != nil:
+			addPath(i, "serviceAccountToken", source.ServiceAccountToken.Audience, source.ServiceAccountToken.Path)
+		default:
+			allErrs = append(allErrs, field.Required(fldPath.Index(i), "must specify a projected volume source"))
+		}
+	}
+
+	for targetPath, sources := range owners {
+		if len(sources) > 1 {
+			allErrs = append(allErrs, field.Invalid(fldPath, targetPath, fmt.Sprintf("path is projected by more than one source: %s", strings.Join(sources, ", "))))
+		}
+	}
+
+	return allErrs
+}
+
+// validateSystemProjectedPath rejects absolute paths and paths that escape
+// the volume root via "..", matching the restrictions enforced again at
+// mount time in pkg/volume/system.
+func validateSystemProjectedPath(targetPath string) error {
+	if targetPath == "" {
+		return fmt.Errorf("must not be empty")
+	}
+	if path.IsAbs(targetPath) {
+		return fmt.Errorf("must be a relative path")
+	}
+	for _, item := range strings.Split(targetPath, "/") {
+		if item == ".." {
+			return fmt.Errorf("must not contain '..'")
+		}
+	}
+	return nil
+}
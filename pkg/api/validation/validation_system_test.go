@@ -0,0 +1,104 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/util/validation/field"
+)
+
+func TestValidateSystemVolumeSourceCollidingPaths(t *testing.T) {
+	system := &api.SystemProjections{
+		Sources: []api.SystemVolumeProjection{
+			{Secret: &api.SecretProjection{SecretName: "a", Items: []api.KeyToPath{{Key: "k", Path: "shared"}}}},
+			{ConfigMap: &api.ConfigMapProjection{Name: "b", Items: []api.KeyToPath{{Key: "k", Path: "shared"}}}},
+		},
+	}
+
+	errs := validateSystemVolumeSource(system, field.NewPath("sources"))
+	if len(errs) != 1 {
+		t.Fatalf("expected exactly one error for colliding paths, got %v", errs)
+	}
+}
+
+func TestValidateSystemVolumeSourceCollidingEquivalentPaths(t *testing.T) {
+	// "foo", "./foo" and "a/b/" / "a/b" are the same target path once
+	// cleaned, and must collide here the same way they do at mount time
+	// in pkg/volume/system's claimPath, or a spec could pass admission
+	// and still fail SetUpAt on the node.
+	cases := [][2]string{
+		{"foo", "./foo"},
+		{"a/b", "a/b/"},
+		{"a/./b", "a/b"},
+	}
+	for _, tc := range cases {
+		system := &api.SystemProjections{
+			Sources: []api.SystemVolumeProjection{
+				{Secret: &api.SecretProjection{SecretName: "a", Items: []api.KeyToPath{{Key: "k", Path: tc[0]}}}},
+				{ConfigMap: &api.ConfigMapProjection{Name: "b", Items: []api.KeyToPath{{Key: "k", Path: tc[1]}}}},
+			},
+		}
+		if errs := validateSystemVolumeSource(system, field.NewPath("sources")); len(errs) != 1 {
+			t.Errorf("paths %q and %q: expected exactly one collision error, got %v", tc[0], tc[1], errs)
+		}
+	}
+}
+
+func TestValidateSystemVolumeSourceEscapingPath(t *testing.T) {
+	cases := []string{"../escape", "/absolute", "nested/../../escape"}
+	for _, p := range cases {
+		system := &api.SystemProjections{
+			Sources: []api.SystemVolumeProjection{
+				{Secret: &api.SecretProjection{SecretName: "a", Items: []api.KeyToPath{{Key: "k", Path: p}}}},
+			},
+		}
+		if errs := validateSystemVolumeSource(system, field.NewPath("sources")); len(errs) == 0 {
+			t.Errorf("expected path %q to be rejected", p)
+		}
+	}
+}
+
+func TestValidateSystemVolumeSourceValid(t *testing.T) {
+	system := &api.SystemProjections{
+		Sources: []api.SystemVolumeProjection{
+			{Secret: &api.SecretProjection{SecretName: "a", Items: []api.KeyToPath{{Key: "k", Path: "secret-file"}}}},
+			{ConfigMap: &api.ConfigMapProjection{Name: "b", Items: []api.KeyToPath{{Key: "k", Path: "configmap-file"}}}},
+			{ServiceAccountToken: &api.ServiceAccountTokenProjection{Audience: "api", Path: "token"}},
+		},
+	}
+
+	if errs := validateSystemVolumeSource(system, field.NewPath("sources")); len(errs) != 0 {
+		t.Errorf("expected no errors for a valid, non-colliding spec, got %v", errs)
+	}
+}
+
+func TestValidateVolumeSourceWiresSystemProjection(t *testing.T) {
+	source := &api.VolumeSource{
+		SystemProjection: &api.SystemProjections{
+			Sources: []api.SystemVolumeProjection{
+				{Secret: &api.SecretProjection{SecretName: "a", Items: []api.KeyToPath{{Key: "k", Path: "shared"}}}},
+				{ConfigMap: &api.ConfigMapProjection{Name: "b", Items: []api.KeyToPath{{Key: "k", Path: "shared"}}}},
+			},
+		},
+	}
+
+	if errs := ValidateVolumeSource(source, field.NewPath("volumeSource")); len(errs) == 0 {
+		t.Errorf("expected ValidateVolumeSource to surface the SystemProjection collision, got no errors")
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authentication
+
+import (
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/types"
+)
+
+// TokenRequest requests a token for a given service account.
+type TokenRequest struct {
+	unversioned.TypeMeta
+	ObjectMeta
+
+	Spec   TokenRequestSpec
+	Status TokenRequestStatus
+}
+
+// ObjectMeta is the subset of metav1.ObjectMeta a TokenRequest needs: the
+// name of the ServiceAccount the token is requested for, carried as the
+// subresource's owning object.
+type ObjectMeta struct {
+	Name      string
+	Namespace string
+}
+
+// TokenRequestSpec contains client provided parameters of a token request.
+type TokenRequestSpec struct {
+	// Audiences are the intendend audiences of the token. A recipient of
+	// a token must identify itself with an identifier in the list of
+	// audiences of the token, and otherwise should reject the token.
+	Audiences []string
+	// ExpirationSeconds is the requested duration of validity of the
+	// request. The token issuer may return a token with a different
+	// validity duration so a client needs to check the 'expiration'
+	// field in a response.
+	ExpirationSeconds *int64
+	// BoundObjectRef is a reference to an object that the token will be
+	// bound to. The token will only be valid for as long as the bound
+	// object exists, and will be invalidated as soon as the bound
+	// object is deleted.
+	BoundObjectRef *BoundObjectReference
+}
+
+// TokenRequestStatus is the result of a token request.
+type TokenRequestStatus struct {
+	// Token is the opaque bearer token.
+	Token string
+	// ExpirationTimestamp is the time of expiration of the returned
+	// token.
+	ExpirationTimestamp unversioned.Time
+}
+
+// BoundObjectReference is a reference to an object that a token is bound
+// to.
+type BoundObjectReference struct {
+	// Kind of the referent, e.g. "Pod".
+	Kind string
+	// APIVersion of the referent.
+	APIVersion string
+	Name       string
+	UID        types.UID
+}
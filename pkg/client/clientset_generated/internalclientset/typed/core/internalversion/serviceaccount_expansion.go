@@ -0,0 +1,44 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internalversion
+
+import (
+	authenticationapi "k8s.io/kubernetes/pkg/apis/authentication"
+)
+
+// ServiceAccountExpansion has methods that aren't generated automatically
+// because ServiceAccounts' CreateToken is a subresource rather than a
+// standard verb on the resource itself.
+type ServiceAccountExpansion interface {
+	CreateToken(serviceAccountName string, tr *authenticationapi.TokenRequest) (*authenticationapi.TokenRequest, error)
+}
+
+// CreateToken requests a token for serviceAccountName bound to the object
+// referenced by tr.Spec.BoundObjectRef, via the ServiceAccount's "token"
+// subresource.
+func (c *serviceAccounts) CreateToken(serviceAccountName string, tr *authenticationapi.TokenRequest) (result *authenticationapi.TokenRequest, err error) {
+	result = &authenticationapi.TokenRequest{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("serviceaccounts").
+		Name(serviceAccountName).
+		SubResource("token").
+		Body(tr).
+		Do().
+		Into(result)
+	return
+}
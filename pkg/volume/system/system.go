@@ -21,9 +21,13 @@ import (
 	"path"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/golang/glog"
 	"k8s.io/kubernetes/pkg/api"
+	authenticationapi "k8s.io/kubernetes/pkg/apis/authentication"
+	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
 	"k8s.io/kubernetes/pkg/fieldpath"
 	"k8s.io/kubernetes/pkg/types"
 	utilerrors "k8s.io/kubernetes/pkg/util/errors"
@@ -41,8 +45,61 @@ func ProbeVolumePlugins() []volume.VolumePlugin {
 
 const (
 	systemPluginName = "kubernetes.io/system"
+
+	// defaultTokenExpirationSeconds is used when a ServiceAccountToken
+	// source doesn't specify ExpirationSeconds.
+	defaultTokenExpirationSeconds int64 = 3600
+
+	// defaultMinTokenExpirationSeconds and defaultMaxTokenExpirationSeconds
+	// are the out-of-the-box bounds on the expirationSeconds a
+	// ServiceAccountToken source may request, used until overridden by
+	// SetTokenExpirationBounds.
+	defaultMinTokenExpirationSeconds int64 = 10 * 60
+	defaultMaxTokenExpirationSeconds int64 = 2 << 31
+
+	tokenRefreshBackoffInitial = 1 * time.Second
+	tokenRefreshBackoffMax     = 1 * time.Minute
+)
+
+// minTokenExpirationSeconds and maxTokenExpirationSeconds bound the
+// expirationSeconds a ServiceAccountToken source may request, mirroring
+// the range enforced by the TokenRequest API itself. They're variables
+// rather than consts so they can be overridden via SetTokenExpirationBounds.
+var (
+	minTokenExpirationSeconds = defaultMinTokenExpirationSeconds
+	maxTokenExpirationSeconds = defaultMaxTokenExpirationSeconds
+)
+
+// SetTokenExpirationBounds overrides the default min/max expirationSeconds
+// accepted for a ServiceAccountToken source, for all mounters created by
+// this plugin. Nothing in this package calls it; it is left to callers
+// such as tests.
+func SetTokenExpirationBounds(min, max int64) {
+	minTokenExpirationSeconds = min
+	maxTokenExpirationSeconds = max
+}
+
+// tokenState tracks the most recently issued token for a projected
+// ServiceAccountToken source so repeated calls to SetUpAt (driven by
+// RequiresRemount) know whether a refresh is due yet, and so a failed
+// refresh can fall back to serving the last good token instead of an
+// empty file.
+type tokenState struct {
+	token     string
+	expiresAt time.Time
+	refreshAt time.Time
+	backoff   time.Duration
+}
+
+var (
+	tokenStatesLock sync.Mutex
+	tokenStates     = make(map[string]*tokenState)
 )
 
+func tokenStateKey(podUID types.UID, volName string) string {
+	return string(podUID) + "/" + volName
+}
+
 type systemPlugin struct {
 	host volume.VolumeHost
 }
@@ -90,27 +147,31 @@ func (plugin *systemPlugin) RequiresRemount() bool {
 }
 
 func (plugin *systemPlugin) NewMounter(spec *volume.Spec, pod *api.Pod, opts volume.VolumeOptions) (volume.Mounter, error) {
+	sv := &systemVolume{
+		volName: spec.Name(),
+		sources: spec.Volume.SystemProjection.Sources,
+		podUID:  pod.UID,
+		plugin:  plugin,
+	}
+	sv.metrics = volume.NewMetricsDu(sv.GetPath())
+
 	return &systemVolumeMounter{
-		systemVolume: &systemVolume{
-			volName: spec.Name(),
-			sources: spec.Volume.SystemProjection.Sources,
-			podUID:  pod.UID,
-			plugin:  plugin,
-		},
-		source: *spec.Volume.SystemProjection,
-		pod:    pod,
-		opts:   &opts,
+		systemVolume: sv,
+		source:       *spec.Volume.SystemProjection,
+		pod:          pod,
+		opts:         &opts,
 	}, nil
 }
 
 func (plugin *systemPlugin) NewUnmounter(volName string, podUID types.UID) (volume.Unmounter, error) {
-	return &systemVolumeUnmounter{
-		&systemVolume{
-			volName: volName,
-			podUID:  podUID,
-			plugin:  plugin,
-		},
-	}, nil
+	sv := &systemVolume{
+		volName: volName,
+		podUID:  podUID,
+		plugin:  plugin,
+	}
+	sv.metrics = volume.NewMetricsDu(sv.GetPath())
+
+	return &systemVolumeUnmounter{sv}, nil
 }
 
 func (plugin *systemPlugin) ConstructVolumeSpec(volumeName, mountPath string) (*volume.Spec, error) {
@@ -131,7 +192,7 @@ type systemVolume struct {
 	plugin  *systemPlugin
 	//	mounter           mount.Interface
 	//	writer            ioutil.Writer
-	volume.MetricsNil
+	metrics volume.MetricsProvider
 }
 
 var _ volume.Volume = &systemVolume{}
@@ -140,6 +201,13 @@ func (sv *systemVolume) GetPath() string {
 	return getPath(sv.podUID, sv.volName, sv.plugin.host)
 }
 
+// GetMetrics returns the disk usage of the tmpfs-backed emptyDir this
+// volume is wrapped around, so the Summary API and cAdvisor can report on
+// projected system volumes the same way they do for other volume types.
+func (sv *systemVolume) GetMetrics() (*volume.Metrics, error) {
+	return sv.metrics.GetMetrics()
+}
+
 type systemVolumeMounter struct {
 	*systemVolume
 
@@ -184,6 +252,7 @@ func (s *systemVolumeMounter) SetUpAt(dir string, fsGroup *int64) error {
 	data, err := s.collectData(s.source.DefaultMode)
 	if err != nil {
 		glog.Errorf("Error preparing data for system volume %v for pod %v/%v: %s", s.volName, s.pod.Namespace, s.pod.Name, err.Error())
+		return err
 	}
 
 	writerContext := fmt.Sprintf("pod %v/%v volume %v", s.pod.Namespace, s.pod.Name, s.volName)
@@ -220,6 +289,33 @@ func (s *systemVolumeMounter) collectData(defaultMode *int32) (map[string]volume
 
 	errlist := []error{}
 	payload := make(map[string]volumeutil.FileProjection)
+	pathOwners := make(map[string]string)
+	dirsCreated := make(map[string]string)
+
+	// claimPath records which source kind owns a target path and fails
+	// the merge if a later source would clobber an earlier one, escape
+	// the volume root, or write through a directory a prior source
+	// already created a file at.
+	claimPath := func(kind, targetPath string) error {
+		cleaned := path.Clean(targetPath)
+		if path.IsAbs(targetPath) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+			return fmt.Errorf("%s path %q escapes the volume root", kind, targetPath)
+		}
+		if owner, ok := pathOwners[cleaned]; ok {
+			return fmt.Errorf("%s and %s both project to path %q", owner, kind, cleaned)
+		}
+		for dir, owner := range dirsCreated {
+			if owner != kind && (cleaned == dir || strings.HasPrefix(cleaned, dir+"/")) {
+				return fmt.Errorf("%s path %q would be written under a directory already created by %s", kind, cleaned, owner)
+			}
+		}
+		pathOwners[cleaned] = kind
+		for dir := path.Dir(cleaned); dir != "." && dir != "/"; dir = path.Dir(dir) {
+			dirsCreated[dir] = kind
+		}
+		return nil
+	}
+
 	for _, source := range s.source.Sources {
 		if source.Secret != nil {
 			// JPEELER: fix this to Secret.Name
@@ -237,6 +333,10 @@ func (s *systemVolumeMounter) collectData(defaultMode *int32) (map[string]volume
 			}
 
 			for k, v := range secretPayload {
+				if err := claimPath(fmt.Sprintf("secret[%s]", source.Secret.SecretName), k); err != nil {
+					errlist = append(errlist, err)
+					continue
+				}
 				payload[k] = v
 			}
 		} else if source.ConfigMap != nil {
@@ -253,6 +353,10 @@ func (s *systemVolumeMounter) collectData(defaultMode *int32) (map[string]volume
 				continue
 			}
 			for k, v := range configMapPayload {
+				if err := claimPath(fmt.Sprintf("configMap[%s]", source.ConfigMap.Name), k); err != nil {
+					errlist = append(errlist, err)
+					continue
+				}
 				payload[k] = v
 			}
 			// uses Items.DownwardAPIVolumeFile
@@ -286,13 +390,167 @@ func (s *systemVolumeMounter) collectData(defaultMode *int32) (map[string]volume
 					}
 				}
 
+				if err := claimPath("downwardAPI", fPath); err != nil {
+					errlist = append(errlist, err)
+					continue
+				}
 				payload[fPath] = fileProjection
 			}
+		} else if source.ServiceAccountToken != nil {
+			fileProjection, err := s.getServiceAccountTokenPayload(source.ServiceAccountToken, defaultMode)
+			if err != nil {
+				glog.Errorf("Couldn't get service account token for pod %v/%v: %v", s.pod.Namespace, s.pod.Name, err)
+				errlist = append(errlist, err)
+				continue
+			}
+			tokenPath := path.Clean(source.ServiceAccountToken.Path)
+			if err := claimPath(fmt.Sprintf("serviceAccountToken[%s]", source.ServiceAccountToken.Audience), tokenPath); err != nil {
+				errlist = append(errlist, err)
+				continue
+			}
+			payload[tokenPath] = fileProjection
 		}
 	}
 	return payload, utilerrors.NewAggregate(errlist)
 }
 
+// getServiceAccountTokenPayload returns the projected file contents for a
+// ServiceAccountToken source, requesting a new token via the TokenRequest
+// API when the previously issued one is due for refresh. Unlike the other
+// sources, tokens expire, so collectData alone can't keep them fresh --
+// the plugin's RequiresRemount causes the kubelet volume manager to call
+// SetUpAt again periodically, and this is where we decide whether that
+// call needs to mint a new token or can reuse the cached one.
+func (s *systemVolumeMounter) getServiceAccountTokenPayload(src *api.ServiceAccountTokenProjection, defaultMode *int32) (volumeutil.FileProjection, error) {
+	var fileProjection volumeutil.FileProjection
+	if src.Mode != nil {
+		fileProjection.Mode = *src.Mode
+	} else {
+		fileProjection.Mode = *defaultMode
+	}
+
+	expirationSeconds := defaultTokenExpirationSeconds
+	if src.ExpirationSeconds != nil {
+		expirationSeconds = *src.ExpirationSeconds
+	}
+	if err := validateExpirationSeconds(expirationSeconds, minTokenExpirationSeconds, maxTokenExpirationSeconds); err != nil {
+		return fileProjection, fmt.Errorf("service account token at %v: %v", src.Path, err)
+	}
+
+	key := tokenStateKey(s.podUID, s.volName)
+	tokenStatesLock.Lock()
+	state, found := tokenStates[key]
+	if !found {
+		state = &tokenState{backoff: tokenRefreshBackoffInitial}
+		tokenStates[key] = state
+	}
+	tokenStatesLock.Unlock()
+
+	now := time.Now()
+	if found && now.Before(state.refreshAt) {
+		if state.token == "" {
+			return fileProjection, fmt.Errorf("no service account token issued yet for %v, next request at %v", src.Path, state.refreshAt)
+		}
+		fileProjection.Data = []byte(state.token)
+		return fileProjection, nil
+	}
+
+	token, expiresAt, err := requestServiceAccountToken(s.plugin.host.GetKubeClient(), s.pod, src.Audience, expirationSeconds)
+	if err != nil {
+		tokenStatesLock.Lock()
+		state.backoff = minDuration(state.backoff*2, tokenRefreshBackoffMax)
+		state.refreshAt = now.Add(state.backoff)
+		staleToken := state.token
+		tokenStatesLock.Unlock()
+
+		if staleToken != "" {
+			glog.Errorf("Failed to refresh service account token for pod %v/%v, keeping previous token until retrying at %v: %v", s.pod.Namespace, s.pod.Name, state.refreshAt, err)
+			fileProjection.Data = []byte(staleToken)
+			return fileProjection, nil
+		}
+		return fileProjection, err
+	}
+
+	tokenStatesLock.Lock()
+	state.token = token
+	state.expiresAt = expiresAt
+	state.backoff = tokenRefreshBackoffInitial
+	state.refreshAt = expiresAt.Add(-refreshLeeway(expiresAt.Sub(now)))
+	tokenStatesLock.Unlock()
+
+	fileProjection.Data = []byte(token)
+	return fileProjection, nil
+}
+
+// validateExpirationSeconds reports whether expirationSeconds falls within
+// [min, max], inclusive.
+func validateExpirationSeconds(expirationSeconds, min, max int64) error {
+	if expirationSeconds < min || expirationSeconds > max {
+		return fmt.Errorf("requested expirationSeconds %d is outside the allowed range [%d, %d]", expirationSeconds, min, max)
+	}
+	return nil
+}
+
+// maxTokenAge is the longest a projected token is allowed to go unrotated,
+// regardless of how long-lived the caller requested it to be, matching the
+// rotation behavior documented on api.ServiceAccountTokenProjection.
+const maxTokenAge = 24 * time.Hour
+
+// refreshLeeway returns how long before expiry a token should be renewed:
+// 20% of its ttl, but never so little leeway that the token would go
+// unrotated for longer than maxTokenAge.
+func refreshLeeway(ttl time.Duration) time.Duration {
+	leeway := ttl / 5
+	if ageCap := ttl - maxTokenAge; ageCap > leeway {
+		leeway = ageCap
+	}
+	return leeway
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// requestServiceAccountToken calls the TokenRequest API for the pod's
+// service account once. It deliberately doesn't retry inline: SetUpAt runs
+// synchronously on the kubelet volume-manager goroutine, and getServiceAccountTokenPayload's
+// tokenState.refreshAt/backoff already defers retries to the next
+// RequiresRemount-driven call instead of blocking this one.
+func requestServiceAccountToken(kubeClient internalclientset.Interface, pod *api.Pod, audience string, expirationSeconds int64) (string, time.Time, error) {
+	if kubeClient == nil {
+		return "", time.Time{}, fmt.Errorf("cannot request service account token for pod %v/%v because kube client is not configured", pod.Namespace, pod.Name)
+	}
+
+	// An empty audience leaves Audiences unset so the apiserver applies its
+	// own identifier as the default audience.
+	var audiences []string
+	if audience != "" {
+		audiences = []string{audience}
+	}
+
+	tr := &authenticationapi.TokenRequest{
+		Spec: authenticationapi.TokenRequestSpec{
+			Audiences:         audiences,
+			ExpirationSeconds: &expirationSeconds,
+			BoundObjectRef: &authenticationapi.BoundObjectReference{
+				Kind:       "Pod",
+				APIVersion: "v1",
+				Name:       pod.Name,
+				UID:        pod.UID,
+			},
+		},
+	}
+
+	resp, err := kubeClient.Core().ServiceAccounts(pod.Namespace).CreateToken(pod.Spec.ServiceAccountName, tr)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return resp.Status.Token, resp.Status.ExpirationTimestamp.Time, nil
+}
+
 func sortLines(values string) string {
 	splitted := strings.Split(values, "\n")
 	sort.Strings(splitted)
@@ -312,6 +570,10 @@ func (c *systemVolumeUnmounter) TearDown() error {
 func (c *systemVolumeUnmounter) TearDownAt(dir string) error {
 	glog.V(3).Info("Tearing down volume %v for pod %v at %v", c.volName, c.podUID, dir)
 
+	tokenStatesLock.Lock()
+	delete(tokenStates, tokenStateKey(c.podUID, c.volName))
+	tokenStatesLock.Unlock()
+
 	wrapped, err := c.plugin.host.NewWrapperUnmounter(c.volName, wrappedVolumeSpec(), c.podUID)
 	if err != nil {
 		return err
@@ -0,0 +1,238 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package system
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset/fake"
+	"k8s.io/kubernetes/pkg/types"
+	"k8s.io/kubernetes/pkg/volume"
+	volumetest "k8s.io/kubernetes/pkg/volume/testing"
+)
+
+func TestValidateExpirationSeconds(t *testing.T) {
+	cases := []struct {
+		name              string
+		expirationSeconds int64
+		min, max          int64
+		wantErr           bool
+	}{
+		{"within range", 3600, 600, 1 << 32, false},
+		{"equal to min", 600, 600, 1 << 32, false},
+		{"equal to max", 1 << 32, 600, 1 << 32, false},
+		{"below min", 599, 600, 1 << 32, true},
+		{"above max", 1<<32 + 1, 600, 1 << 32, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateExpirationSeconds(tc.expirationSeconds, tc.min, tc.max)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("validateExpirationSeconds(%d, %d, %d) error = %v, wantErr %v", tc.expirationSeconds, tc.min, tc.max, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetTokenExpirationBounds(t *testing.T) {
+	origMin, origMax := minTokenExpirationSeconds, maxTokenExpirationSeconds
+	defer SetTokenExpirationBounds(origMin, origMax)
+
+	SetTokenExpirationBounds(100, 200)
+	if err := validateExpirationSeconds(150, minTokenExpirationSeconds, maxTokenExpirationSeconds); err != nil {
+		t.Errorf("expected 150 to be within the overridden [100, 200] bound, got error: %v", err)
+	}
+	if err := validateExpirationSeconds(300, minTokenExpirationSeconds, maxTokenExpirationSeconds); err == nil {
+		t.Errorf("expected 300 to be rejected by the overridden [100, 200] bound")
+	}
+}
+
+func TestRefreshLeeway(t *testing.T) {
+	cases := []struct {
+		ttl  time.Duration
+		want time.Duration
+	}{
+		{ttl: 10 * time.Minute, want: 2 * time.Minute},
+		{ttl: time.Hour, want: 12 * time.Minute},
+		{ttl: 24 * time.Hour, want: 24 * time.Hour / 5},
+		// Beyond 24h, the maxTokenAge cap dominates ttl/5: a week-long
+		// token must still be rotated a day after issuance, not 80% of
+		// the way through its week-long life.
+		{ttl: 7 * 24 * time.Hour, want: 7*24*time.Hour - 24*time.Hour},
+		{ttl: 365 * 24 * time.Hour, want: 365*24*time.Hour - 24*time.Hour},
+	}
+	for _, tc := range cases {
+		if got := refreshLeeway(tc.ttl); got != tc.want {
+			t.Errorf("refreshLeeway(%v) = %v, want %v", tc.ttl, got, tc.want)
+		}
+	}
+}
+
+func TestMinDuration(t *testing.T) {
+	if got := minDuration(2*time.Second, 3*time.Second); got != 2*time.Second {
+		t.Errorf("minDuration(2s, 3s) = %v, want 2s", got)
+	}
+	if got := minDuration(5*time.Second, 1*time.Second); got != 1*time.Second {
+		t.Errorf("minDuration(5s, 1s) = %v, want 1s", got)
+	}
+}
+
+// newTestPlugin sets up a systemPlugin against a fake volume host backed by
+// a temp directory, for use by this file's SetUp-driven tests.
+func newTestPlugin(t *testing.T, client *fake.Clientset) (volume.VolumePlugin, volume.VolumeHost, string) {
+	tempDir, err := ioutil.TempDir("", "system_volume_test")
+	if err != nil {
+		t.Fatalf("can't make a temp dir: %v", err)
+	}
+	host := volumetest.NewFakeVolumeHost(tempDir, client, nil)
+	plugMgr := volume.NewVolumePluginMgr()
+	plugMgr.InitPlugins(ProbeVolumePlugins(), nil, host)
+
+	plug, err := plugMgr.FindPluginByName(systemPluginName)
+	if err != nil {
+		os.RemoveAll(tempDir)
+		t.Fatalf("Can't find the plugin by name %q", systemPluginName)
+	}
+	return plug, host, tempDir
+}
+
+func TestMetricsAfterSetUp(t *testing.T) {
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{Name: "pod", Namespace: "test", UID: types.UID("pod-uid")},
+		Spec:       api.PodSpec{ServiceAccountName: "default"},
+	}
+
+	client := fake.NewSimpleClientset()
+	plug, _, tempDir := newTestPlugin(t, client)
+	defer os.RemoveAll(tempDir)
+
+	defaultMode := int32(0644)
+	spec := &volume.Spec{
+		Volume: &api.Volume{
+			Name: "system-volume",
+			VolumeSource: api.VolumeSource{
+				SystemProjection: &api.SystemProjections{DefaultMode: &defaultMode},
+			},
+		},
+	}
+
+	mounter, err := plug.NewMounter(spec, pod, volume.VolumeOptions{})
+	if err != nil {
+		t.Fatalf("Failed to make a new Mounter: %v", err)
+	}
+
+	if err := mounter.SetUp(nil); err != nil {
+		t.Fatalf("Failed to SetUp volume: %v", err)
+	}
+
+	metricsMounter, ok := mounter.(volume.MetricsProvider)
+	if !ok {
+		t.Fatalf("Mounter does not implement volume.MetricsProvider")
+	}
+	metrics, err := metricsMounter.GetMetrics()
+	if err != nil {
+		t.Fatalf("GetMetrics() failed: %v", err)
+	}
+	if metrics.Capacity.IsZero() {
+		t.Errorf("expected non-zero Capacity after SetUp, got %v", metrics.Capacity)
+	}
+}
+
+func TestCollectDataRejectsCollidingPaths(t *testing.T) {
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{Name: "pod", Namespace: "test", UID: types.UID("pod-uid")},
+		Spec:       api.PodSpec{ServiceAccountName: "default"},
+	}
+
+	secretA := &api.Secret{ObjectMeta: api.ObjectMeta{Name: "secret-a", Namespace: "test"}, Data: map[string][]byte{"key": []byte("a")}}
+	secretB := &api.Secret{ObjectMeta: api.ObjectMeta{Name: "secret-b", Namespace: "test"}, Data: map[string][]byte{"key": []byte("b")}}
+	client := fake.NewSimpleClientset(secretA, secretB)
+
+	plug, _, tempDir := newTestPlugin(t, client)
+	defer os.RemoveAll(tempDir)
+
+	defaultMode := int32(0644)
+	spec := &volume.Spec{
+		Volume: &api.Volume{
+			Name: "system-volume",
+			VolumeSource: api.VolumeSource{
+				SystemProjection: &api.SystemProjections{
+					DefaultMode: &defaultMode,
+					Sources: []api.SystemVolumeProjection{
+						{Secret: &api.SecretProjection{SecretName: "secret-a", Items: []api.KeyToPath{{Key: "key", Path: "shared"}}}},
+						{Secret: &api.SecretProjection{SecretName: "secret-b", Items: []api.KeyToPath{{Key: "key", Path: "shared"}}}},
+					},
+				},
+			},
+		},
+	}
+
+	mounter, err := plug.NewMounter(spec, pod, volume.VolumeOptions{})
+	if err != nil {
+		t.Fatalf("Failed to make a new Mounter: %v", err)
+	}
+
+	if err := mounter.SetUp(nil); err == nil {
+		t.Fatalf("expected SetUp to fail on colliding paths, got nil error")
+	} else if !strings.Contains(err.Error(), "shared") {
+		t.Errorf("expected error to mention the colliding path, got: %v", err)
+	}
+}
+
+func TestCollectDataRejectsEscapingPath(t *testing.T) {
+	pod := &api.Pod{
+		ObjectMeta: api.ObjectMeta{Name: "pod", Namespace: "test", UID: types.UID("pod-uid")},
+		Spec:       api.PodSpec{ServiceAccountName: "default"},
+	}
+
+	secretA := &api.Secret{ObjectMeta: api.ObjectMeta{Name: "secret-a", Namespace: "test"}, Data: map[string][]byte{"key": []byte("a")}}
+	client := fake.NewSimpleClientset(secretA)
+
+	plug, _, tempDir := newTestPlugin(t, client)
+	defer os.RemoveAll(tempDir)
+
+	defaultMode := int32(0644)
+	spec := &volume.Spec{
+		Volume: &api.Volume{
+			Name: "system-volume",
+			VolumeSource: api.VolumeSource{
+				SystemProjection: &api.SystemProjections{
+					DefaultMode: &defaultMode,
+					Sources: []api.SystemVolumeProjection{
+						{Secret: &api.SecretProjection{SecretName: "secret-a", Items: []api.KeyToPath{{Key: "key", Path: "../escape"}}}},
+					},
+				},
+			},
+		},
+	}
+
+	mounter, err := plug.NewMounter(spec, pod, volume.VolumeOptions{})
+	if err != nil {
+		t.Fatalf("Failed to make a new Mounter: %v", err)
+	}
+
+	if err := mounter.SetUp(nil); err == nil {
+		t.Fatalf("expected SetUp to fail on an escaping path, got nil error")
+	} else if !strings.Contains(err.Error(), "escapes") {
+		t.Errorf("expected error to mention the path escaping the volume root, got: %v", err)
+	}
+}